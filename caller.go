@@ -0,0 +1,110 @@
+package siglog
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultCallerDepth skips the logical frames for runtime.Callers itself,
+// callerPC, and LogEntryAuto, landing on whoever called LogEntryAuto.
+// SetCallerDepth adjusts this when callers wrap LogEntryAuto in their own
+// helper(s) - the same problem beego's EnableFuncCallDepth solves.
+const defaultCallerDepth = 3
+
+var callerDepth int32 = defaultCallerDepth
+
+// SetCallerDepth sets how many stack frames LogEntryAuto skips to find the
+// real caller. Bump it by one for every helper function that wraps
+// LogEntryAuto.
+func SetCallerDepth(n int) {
+	atomic.StoreInt32(&callerDepth, int32(n))
+}
+
+// LogEntryAuto behaves like LogEntry but fills Entry.Caller automatically
+// from the call stack instead of requiring the caller to pass one by hand.
+// Only the program counter is captured here; resolving it into a string is
+// deferred to format time (see resolveCallerPC) so entries filtered out by
+// the level check never pay for it.
+func LogEntryAuto(entry string, level LogLevel) {
+	if GetLogLevel() == LL_NONE {
+		return
+	}
+
+	dispatchEntry(&Entry{
+		Entry:    entry,
+		Level:    level,
+		CallerPC: callerPC(int(atomic.LoadInt32(&callerDepth))),
+	})
+}
+
+// callerPC walks the goroutine's logical call frames - expanding any the
+// compiler inlined - and returns the PC of the frame at index `depth`,
+// counting runtime.Callers itself as 0 and callerPC as 1 (so depth 2 is
+// callerPC's own caller), or 0 if the stack isn't that deep. Indexing the raw slice
+// runtime.Callers fills in would instead count physical frames, which
+// inlining can collapse unpredictably; CallersFrames is the documented way
+// to get a stable, logical skip count.
+func callerPC(depth int) uintptr {
+	pcs := make([]uintptr, depth+8)
+	n := runtime.Callers(0, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for i := 0; ; i++ {
+		f, more := frames.Next()
+		if i == depth {
+			return f.PC
+		}
+		if !more {
+			return 0
+		}
+	}
+}
+
+// funcCache memoizes runtime.FuncForPC lookups keyed by PC: symbolizing a PC
+// walks the binary's pcln table, which is too slow to redo on every log line
+// sharing a call site.
+var (
+	funcCacheMu sync.RWMutex
+	funcCache   = map[uintptr]*runtime.Func{}
+)
+
+// funcForPC is runtime.FuncForPC with a cache in front of it.
+func funcForPC(pc uintptr) *runtime.Func {
+	funcCacheMu.RLock()
+	fn, ok := funcCache[pc]
+	funcCacheMu.RUnlock()
+	if ok {
+		return fn
+	}
+
+	fn = runtime.FuncForPC(pc)
+
+	funcCacheMu.Lock()
+	funcCache[pc] = fn
+	funcCacheMu.Unlock()
+
+	return fn
+}
+
+// resolveCallerPC renders pc as "file:line" or "pkg.Func" per
+// GetCallerFormat. Returns "" for a zero pc (an Entry not built via
+// LogEntryAuto).
+func resolveCallerPC(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+
+	fn := funcForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+
+	if GetCallerFormat() == CALLER_FUNC {
+		return fn.Name()
+	}
+
+	file, line := fn.FileLine(pc)
+	return fmt.Sprintf("%s:%d", file, line)
+}
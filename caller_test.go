@@ -0,0 +1,75 @@
+package siglog
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogEntryAuto_ResolvesFileLineCaller(t *testing.T) {
+	t.Cleanup(func() {
+		SetBatchMode(BATCH_NONE)
+		SetOutput(OUT_STDOUT)
+		SetLogLevel(LL_NONE)
+		SetCallerFormat(CALLER_FILE_LINE)
+		SetCallerDepth(defaultCallerDepth)
+		resetFormatter()
+	})
+
+	if err := SetLogLevel(LL_DEBUG); err != nil {
+		t.Fatalf("SetLogLevel: %v", err)
+	}
+	if err := SetOutput(OUT_STDOUT); err != nil {
+		t.Fatalf("SetOutput: %v", err)
+	}
+	if err := SetBatchMode(BATCH_NONE); err != nil {
+		t.Fatalf("SetBatchMode: %v", err)
+	}
+
+	got := captureWriter(&os.Stdout, func() {
+		LogEntryAuto("auto caller", LL_DEBUG)
+		Flush()
+	})
+
+	if !strings.Contains(got, "caller_test.go:") {
+		t.Fatalf("expected resolved file:line caller, got:\n%s", got)
+	}
+}
+
+func TestLogEntryAuto_FuncFormat(t *testing.T) {
+	t.Cleanup(func() {
+		SetBatchMode(BATCH_NONE)
+		SetOutput(OUT_STDOUT)
+		SetLogLevel(LL_NONE)
+		SetCallerFormat(CALLER_FILE_LINE)
+		resetFormatter()
+	})
+
+	if err := SetLogLevel(LL_DEBUG); err != nil {
+		t.Fatalf("SetLogLevel: %v", err)
+	}
+	if err := SetOutput(OUT_STDOUT); err != nil {
+		t.Fatalf("SetOutput: %v", err)
+	}
+	if err := SetBatchMode(BATCH_NONE); err != nil {
+		t.Fatalf("SetBatchMode: %v", err)
+	}
+	if err := SetCallerFormat(CALLER_FUNC); err != nil {
+		t.Fatalf("SetCallerFormat: %v", err)
+	}
+
+	got := captureWriter(&os.Stdout, func() {
+		LogEntryAuto("auto caller", LL_DEBUG)
+		Flush()
+	})
+
+	if !strings.Contains(got, "TestLogEntryAuto_FuncFormat") {
+		t.Fatalf("expected resolved function-name caller, got:\n%s", got)
+	}
+}
+
+func TestResolveCallerPC_ZeroIsEmpty(t *testing.T) {
+	if got := resolveCallerPC(0); got != "" {
+		t.Fatalf("expected empty string for a zero pc, got %q", got)
+	}
+}
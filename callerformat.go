@@ -0,0 +1,46 @@
+package siglog
+
+import (
+	"os"
+)
+
+// CallerFormat selects how a program counter resolved by LogEntryAuto is
+// rendered into a string.
+type CallerFormat int
+
+const (
+	CALLER_FILE_LINE CallerFormat = iota
+	CALLER_FUNC
+)
+
+var callerFormatName = map[CallerFormat]string{
+	CALLER_FILE_LINE: "FILE_LINE",
+	CALLER_FUNC:      "FUNC",
+}
+
+var callerFormatValue = map[string]CallerFormat{
+	"FILE_LINE": CALLER_FILE_LINE,
+	"FUNC":      CALLER_FUNC,
+}
+
+func (c CallerFormat) String() string {
+	return callerFormatName[c]
+}
+
+const (
+	ENV_SL_CALLER_FORMAT = "ENV_SL_CALLER_FORMAT"
+)
+
+func GetCallerFormat() CallerFormat {
+	token := os.Getenv(ENV_SL_CALLER_FORMAT)
+	if token == "" {
+		SetCallerFormat(CALLER_FILE_LINE)
+		return CALLER_FILE_LINE
+	}
+
+	return callerFormatValue[token]
+}
+
+func SetCallerFormat(c CallerFormat) error {
+	return os.Setenv(ENV_SL_CALLER_FORMAT, c.String())
+}
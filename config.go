@@ -0,0 +1,297 @@
+package siglog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the declarative shape accepted by LoadConfig/LoadConfigBytes: the
+// global level, batching and rotation params, log directory, formatter
+// choice, and the set of sinks to run with their own per-sink levels and
+// options. Any other zero-valued field is left at its current setting rather
+// than being reset. Formatter and Sinks are always applied: an empty
+// Formatter installs the default formatter, and Sinks replaces every
+// registered sink, so a config that lists no sinks removes them all.
+type Config struct {
+	Level        string `json:"level,omitempty" yaml:"level,omitempty"`
+	LogDirectory string `json:"log_directory,omitempty" yaml:"log_directory,omitempty"`
+	Output       string `json:"output,omitempty" yaml:"output,omitempty"`
+	Formatter    string `json:"formatter,omitempty" yaml:"formatter,omitempty"`
+	Pattern      string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+
+	Batch    BatchConfig    `json:"batch,omitempty" yaml:"batch,omitempty"`
+	Rotation RotationConfig `json:"rotation,omitempty" yaml:"rotation,omitempty"`
+	Sinks    []SinkConfig   `json:"sinks,omitempty" yaml:"sinks,omitempty"`
+}
+
+// BatchConfig mirrors SetBatchMode/SetMaxItems/SetMaxBytes/SetMaxWait.
+type BatchConfig struct {
+	Mode      string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	MaxItems  int    `json:"max_items,omitempty" yaml:"max_items,omitempty"`
+	MaxBytes  int    `json:"max_bytes,omitempty" yaml:"max_bytes,omitempty"`
+	MaxWaitMS int    `json:"max_wait_ms,omitempty" yaml:"max_wait_ms,omitempty"`
+}
+
+// RotationConfig mirrors SetMaxFileBytes/SetMaxFileLines/SetMaxRetentionDays.
+type RotationConfig struct {
+	MaxFileBytes     int64 `json:"max_file_bytes,omitempty" yaml:"max_file_bytes,omitempty"`
+	MaxFileLines     int   `json:"max_file_lines,omitempty" yaml:"max_file_lines,omitempty"`
+	MaxRetentionDays int   `json:"max_retention_days,omitempty" yaml:"max_retention_days,omitempty"`
+}
+
+// SinkConfig declares one entry of AddSink. Type selects a built-in Sink
+// constructor ("stdout", "stderr", "file", "network"); Options carries the
+// constructor's arguments (e.g. "path" for file, "network"/"addr" for
+// network).
+type SinkConfig struct {
+	Name    string            `json:"name" yaml:"name"`
+	Type    string            `json:"type" yaml:"type"`
+	Level   string            `json:"level" yaml:"level"`
+	Options map[string]string `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// LoadConfig reads path and applies it, choosing JSON or YAML based on the
+// file extension (.yaml/.yml vs everything else).
+func LoadConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	format := "json"
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = "yaml"
+	}
+
+	return LoadConfigBytes(data, format)
+}
+
+// LoadConfigBytes parses data as the given format ("json" or "yaml") and
+// atomically applies it: settings only change once the whole document has
+// parsed and validated successfully.
+func LoadConfigBytes(data []byte, format string) error {
+	var cfg Config
+
+	switch strings.ToLower(format) {
+	case "json", "":
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return fmt.Errorf("siglog: parse json config: %w", err)
+		}
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return fmt.Errorf("siglog: parse yaml config: %w", err)
+		}
+	default:
+		return fmt.Errorf("siglog: unknown config format %q", format)
+	}
+
+	return applyConfig(&cfg)
+}
+
+// applyConfig validates cfg in full, building every sink it names, before
+// changing any global state. That keeps a malformed config from partially
+// applying and leaving the logger half-reconfigured.
+func applyConfig(cfg *Config) error {
+	var level LogLevel
+	if cfg.Level != "" {
+		var ok bool
+		level, ok = levelValue[strings.ToUpper(cfg.Level)]
+		if !ok {
+			return fmt.Errorf("siglog: unknown level %q", cfg.Level)
+		}
+	}
+
+	var output Output
+	if cfg.Output != "" {
+		var ok bool
+		output, ok = outValue[strings.ToUpper(cfg.Output)]
+		if !ok {
+			return fmt.Errorf("siglog: unknown output %q", cfg.Output)
+		}
+	}
+
+	var batchMode BatchMode
+	if cfg.Batch.Mode != "" {
+		var ok bool
+		batchMode, ok = batchValue[strings.ToUpper(cfg.Batch.Mode)]
+		if !ok {
+			return fmt.Errorf("siglog: unknown batch mode %q", cfg.Batch.Mode)
+		}
+	}
+
+	formatterName := strings.ToLower(cfg.Formatter)
+	var formatter LogFormatter
+	switch formatterName {
+	case "":
+		formatterName = "default"
+		formatter = defaultFormatByLevel
+	case "default":
+		formatter = defaultFormatByLevel
+	case "json":
+		formatter = JSONFormatter
+	case "pattern":
+		if cfg.Pattern == "" {
+			return fmt.Errorf("siglog: formatter \"pattern\" requires a pattern string")
+		}
+		pf, err := PatternFormatter(cfg.Pattern)
+		if err != nil {
+			return err
+		}
+		formatter = pf
+	default:
+		return fmt.Errorf("siglog: unknown formatter %q", cfg.Formatter)
+	}
+
+	newSinks := make(map[string]*registeredSink, len(cfg.Sinks))
+	for _, sc := range cfg.Sinks {
+		min, ok := levelValue[strings.ToUpper(sc.Level)]
+		if sc.Level != "" && !ok {
+			closeSinks(newSinks)
+			return fmt.Errorf("siglog: sink %q: unknown level %q", sc.Name, sc.Level)
+		}
+
+		s, err := buildConfigSink(sc)
+		if err != nil {
+			closeSinks(newSinks)
+			return fmt.Errorf("siglog: sink %q: %w", sc.Name, err)
+		}
+		newSinks[sc.Name] = &registeredSink{
+			sink: s, min: min,
+			cfgType: sc.Type, cfgOpts: sc.Options,
+		}
+	}
+
+	if cfg.LogDirectory != "" {
+		if err := os.MkdirAll(cfg.LogDirectory, 0755); err != nil {
+			closeSinks(newSinks)
+			return err
+		}
+	}
+
+	// Everything above validated cleanly; only now do we touch global state.
+	if cfg.Level != "" {
+		_ = SetLogLevel(level)
+	}
+	if cfg.LogDirectory != "" {
+		if err := SetLogDirectory(cfg.LogDirectory); err != nil {
+			return err
+		}
+	}
+	if cfg.Output != "" {
+		_ = SetOutput(output)
+	}
+	SetLogFormatter(formatter)
+	setFormatterName(formatterName)
+	if formatterName == "pattern" {
+		setFormatterPattern(cfg.Pattern)
+	} else {
+		setFormatterPattern("")
+	}
+
+	if cfg.Batch.Mode != "" {
+		_ = SetBatchMode(batchMode)
+	}
+	if cfg.Batch.MaxItems > 0 {
+		SetMaxItems(cfg.Batch.MaxItems)
+	}
+	if cfg.Batch.MaxBytes > 0 {
+		SetMaxBytes(cfg.Batch.MaxBytes)
+	}
+	if cfg.Batch.MaxWaitMS > 0 {
+		SetMaxWait(time.Duration(cfg.Batch.MaxWaitMS) * time.Millisecond)
+	}
+
+	if cfg.Rotation.MaxFileBytes > 0 {
+		SetMaxFileBytes(cfg.Rotation.MaxFileBytes)
+	}
+	if cfg.Rotation.MaxFileLines > 0 {
+		SetMaxFileLines(cfg.Rotation.MaxFileLines)
+	}
+	if cfg.Rotation.MaxRetentionDays > 0 {
+		SetMaxRetentionDays(cfg.Rotation.MaxRetentionDays)
+	}
+
+	swapSinks(newSinks)
+
+	return nil
+}
+
+// closeSinks closes every sink already built for a config reload that failed
+// validation partway through cfg.Sinks, so a rejected reload doesn't leak an
+// open file handle or a NetworkSink's dial/backoff goroutine.
+func closeSinks(built map[string]*registeredSink) {
+	for _, rs := range built {
+		rs.sink.Close()
+	}
+}
+
+// buildConfigSink constructs the built-in Sink named by sc.Type.
+func buildConfigSink(sc SinkConfig) (Sink, error) {
+	switch strings.ToLower(sc.Type) {
+	case "stdout":
+		return NewStdoutSink(), nil
+	case "stderr":
+		return NewStderrSink(), nil
+	case "file":
+		path := sc.Options["path"]
+		if path == "" {
+			return nil, fmt.Errorf("requires options.path")
+		}
+		return NewFileSink(path)
+	case "network":
+		network := sc.Options["network"]
+		if network == "" {
+			network = "tcp"
+		}
+		addr := sc.Options["addr"]
+		if addr == "" {
+			return nil, fmt.Errorf("requires options.addr")
+		}
+		return NewNetworkSink(network, addr), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", sc.Type)
+	}
+}
+
+// DumpConfig snapshots the current runtime configuration as indented JSON,
+// in the same shape LoadConfig accepts, for debugging.
+func DumpConfig() ([]byte, error) {
+	cfg := Config{
+		Level:        GetLogLevel().String(),
+		LogDirectory: getLogDirectory(),
+		Output:       GetOutput().String(),
+		Formatter:    getFormatterName(),
+		Pattern:      getFormatterPattern(),
+		Batch: BatchConfig{
+			Mode:      GetBatchMode().String(),
+			MaxItems:  globalLogger.maxItems,
+			MaxBytes:  globalLogger.maxBytes,
+			MaxWaitMS: int(globalLogger.maxWait / time.Millisecond),
+		},
+		Rotation: RotationConfig{
+			MaxFileBytes:     globalLogger.maxFileBytes,
+			MaxFileLines:     globalLogger.maxFileLines,
+			MaxRetentionDays: globalLogger.maxRetentionDays,
+		},
+	}
+
+	sinkMu.RLock()
+	for name, rs := range sinks {
+		cfg.Sinks = append(cfg.Sinks, SinkConfig{
+			Name:    name,
+			Type:    rs.cfgType,
+			Level:   rs.min.String(),
+			Options: rs.cfgOpts,
+		})
+	}
+	sinkMu.RUnlock()
+
+	return json.MarshalIndent(cfg, "", "  ")
+}
@@ -0,0 +1,108 @@
+package siglog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLoadConfigBytes_JSON_AppliesSettingsAndSinks(t *testing.T) {
+	t.Cleanup(func() {
+		SetLogLevel(LL_NONE)
+		SetOutput(OUT_STDOUT)
+		SetBatchMode(BATCH_NONE)
+		SetLogFormatter(defaultFormatByLevel)
+		setFormatterName("default")
+		swapSinks(map[string]*registeredSink{})
+	})
+
+	const doc = `{
+		"level": "DEBUG",
+		"output": "STDOUT",
+		"formatter": "json",
+		"batch": {"mode": "ITEM", "max_items": 7},
+		"sinks": [
+			{"name": "errors", "type": "stderr", "level": "ERROR"}
+		]
+	}`
+
+	if err := LoadConfigBytes([]byte(doc), "json"); err != nil {
+		t.Fatalf("LoadConfigBytes: %v", err)
+	}
+
+	if GetLogLevel() != LL_DEBUG {
+		t.Fatalf("expected level DEBUG, got %s", GetLogLevel())
+	}
+	if GetBatchMode() != BATCH_ITEM {
+		t.Fatalf("expected batch mode ITEM, got %s", GetBatchMode())
+	}
+	if globalLogger.maxItems != 7 {
+		t.Fatalf("expected maxItems 7, got %d", globalLogger.maxItems)
+	}
+	if getFormatterName() != "json" {
+		t.Fatalf("expected formatter name json, got %q", getFormatterName())
+	}
+
+	sinkMu.RLock()
+	_, ok := sinks["errors"]
+	sinkMu.RUnlock()
+	if !ok {
+		t.Fatalf("expected config to register the 'errors' sink")
+	}
+}
+
+func TestLoadConfigBytes_JSON_AppliesPatternFormatter(t *testing.T) {
+	t.Cleanup(func() {
+		SetLogFormatter(defaultFormatByLevel)
+		setFormatterName("default")
+		setFormatterPattern("")
+	})
+
+	const doc = `{"formatter": "pattern", "pattern": "%L: %M"}`
+	if err := LoadConfigBytes([]byte(doc), "json"); err != nil {
+		t.Fatalf("LoadConfigBytes: %v", err)
+	}
+
+	if getFormatterName() != "pattern" {
+		t.Fatalf("expected formatter name pattern, got %q", getFormatterName())
+	}
+	if getFormatterPattern() != "%L: %M" {
+		t.Fatalf("expected stored pattern %%L: %%M, got %q", getFormatterPattern())
+	}
+
+	out, err := formatByLevel(Entry{Entry: "hi", Level: LL_WARN})
+	if err != nil {
+		t.Fatalf("formatByLevel: %v", err)
+	}
+	if out != "WARN: hi\n" {
+		t.Fatalf("unexpected formatted output: %q", out)
+	}
+}
+
+func TestLoadConfigBytes_RejectsUnknownLevel(t *testing.T) {
+	if err := LoadConfigBytes([]byte(`{"level": "VERBOSE"}`), "json"); err == nil {
+		t.Fatalf("expected an error for an unknown level")
+	}
+}
+
+func TestDumpConfig_RoundTripsThroughJSON(t *testing.T) {
+	t.Cleanup(func() {
+		SetLogLevel(LL_NONE)
+	})
+
+	if err := SetLogLevel(LL_WARN); err != nil {
+		t.Fatalf("SetLogLevel: %v", err)
+	}
+
+	data, err := DumpConfig()
+	if err != nil {
+		t.Fatalf("DumpConfig: %v", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("dumped config is not valid JSON: %v", err)
+	}
+	if cfg.Level != "WARN" {
+		t.Fatalf("expected dumped level WARN, got %q", cfg.Level)
+	}
+}
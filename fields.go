@@ -0,0 +1,62 @@
+package siglog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Field is a single key/value pair attached to a log Entry. Passing
+// []Field via LogEntryWith's variadic spares the caller from building a map
+// literal for structured context; LogEntryWith still copies the fields into
+// Entry.Fields, a map it allocates on every call that passes any fields.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// F constructs a Field, so call sites read as
+// LogEntryWith(caller, level, msg, siglog.F("request_id", id)).
+func F(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// LogEntryWith behaves like LogEntry but attaches structured key/value
+// fields to the Entry instead of requiring callers to stuff them into the
+// message string.
+func LogEntryWith(caller string, level LogLevel, msg string, fields ...Field) {
+	if GetLogLevel() == LL_NONE {
+		return
+	}
+
+	le := &Entry{
+		Caller: caller,
+		Entry:  msg,
+		Level:  level,
+	}
+
+	if len(fields) > 0 {
+		le.Fields = make(map[string]any, len(fields))
+		for _, f := range fields {
+			le.Fields[f.Key] = f.Value
+		}
+	}
+
+	dispatchEntry(le)
+}
+
+// formatFields renders fields as space-separated "key=value" pairs, sorted
+// by key so output is deterministic across runs.
+func formatFields(fields map[string]any) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,70 @@
+package siglog
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogEntryWith_RendersFieldsAsKeyValue(t *testing.T) {
+	t.Cleanup(func() {
+		SetBatchMode(BATCH_NONE)
+		SetOutput(OUT_STDOUT)
+		SetLogLevel(LL_NONE)
+		resetFormatter()
+	})
+
+	if err := SetLogLevel(LL_DEBUG); err != nil {
+		t.Fatalf("SetLogLevel: %v", err)
+	}
+	if err := SetOutput(OUT_STDOUT); err != nil {
+		t.Fatalf("SetOutput: %v", err)
+	}
+	if err := SetBatchMode(BATCH_NONE); err != nil {
+		t.Fatalf("SetBatchMode: %v", err)
+	}
+
+	got := captureWriter(&os.Stdout, func() {
+		LogEntryWith("SYSTEM", LL_DEBUG, "hello", F("request_id", "abc"), F("latency_ms", 12))
+		Flush()
+	})
+
+	if !strings.Contains(got, "[SYSTEM][DEBUG] - hello latency_ms=12 request_id=abc\n") {
+		t.Fatalf("unexpected stdout content:\n%s", got)
+	}
+}
+
+func TestJSONFormatter_MergesFields(t *testing.T) {
+	t.Cleanup(func() {
+		SetBatchMode(BATCH_NONE)
+		SetOutput(OUT_STDOUT)
+		SetLogLevel(LL_NONE)
+		resetFormatter()
+	})
+
+	if err := SetLogLevel(LL_DEBUG); err != nil {
+		t.Fatalf("SetLogLevel: %v", err)
+	}
+	if err := SetOutput(OUT_STDOUT); err != nil {
+		t.Fatalf("SetOutput: %v", err)
+	}
+	if err := SetBatchMode(BATCH_NONE); err != nil {
+		t.Fatalf("SetBatchMode: %v", err)
+	}
+	SetLogFormatter(JSONFormatter)
+
+	got := captureWriter(&os.Stdout, func() {
+		LogEntryWith("SYSTEM", LL_DEBUG, "hello", F("request_id", "abc"))
+		Flush()
+	})
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(got)), &decoded); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", got, err)
+	}
+
+	if decoded["msg"] != "hello" || decoded["caller"] != "SYSTEM" || decoded["level"] != "DEBUG" || decoded["request_id"] != "abc" {
+		t.Fatalf("unexpected decoded fields: %+v", decoded)
+	}
+}
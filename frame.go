@@ -0,0 +1,53 @@
+package siglog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxFrameBytes guards against a corrupt or malicious length prefix causing
+// an unbounded allocation while decoding.
+const maxFrameBytes = 1 << 20 // 1MiB
+
+// EncodeFrame renders e as a length-prefixed JSON frame: a 4-byte
+// big-endian length followed by the JSON payload. This is the wire format
+// shared by NetworkSink and the siglog/netlog receiver.
+func EncodeFrame(e Entry) ([]byte, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := make([]byte, 4+len(payload))
+	binary.BigEndian.PutUint32(frame[:4], uint32(len(payload)))
+	copy(frame[4:], payload)
+	return frame, nil
+}
+
+// DecodeFrame reads a single length-prefixed JSON frame from r and decodes
+// it back into an Entry.
+func DecodeFrame(r *bufio.Reader) (Entry, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return Entry{}, err
+	}
+
+	n := binary.BigEndian.Uint32(lenBuf[:])
+	if n > maxFrameBytes {
+		return Entry{}, fmt.Errorf("siglog: frame of %d bytes exceeds max %d", n, maxFrameBytes)
+	}
+
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return Entry{}, err
+	}
+
+	var e Entry
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return Entry{}, err
+	}
+	return e, nil
+}
@@ -0,0 +1,34 @@
+package siglog
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+func TestEncodeDecodeFrame_RoundTrip(t *testing.T) {
+	want := Entry{Caller: "SYSTEM", Entry: "shipped\n", Level: LL_WARN}
+
+	frame, err := EncodeFrame(want)
+	if err != nil {
+		t.Fatalf("EncodeFrame: %v", err)
+	}
+
+	got, err := DecodeFrame(bufio.NewReader(bytes.NewReader(frame)))
+	if err != nil {
+		t.Fatalf("DecodeFrame: %v", err)
+	}
+
+	if got.Caller != want.Caller || got.Entry != want.Entry || got.Level != want.Level {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeFrame_RejectsOversizedLength(t *testing.T) {
+	var frame [4]byte
+	frame[0] = 0xFF // length prefix far larger than maxFrameBytes
+
+	if _, err := DecodeFrame(bufio.NewReader(bytes.NewReader(frame[:]))); err == nil {
+		t.Fatalf("expected an error for an oversized frame length")
+	}
+}
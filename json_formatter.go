@@ -0,0 +1,35 @@
+package siglog
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// JSONFormatter is a LogFormatter that renders an Entry as a single JSON
+// object with "ts", "level", "caller", "msg", plus any structured Fields
+// merged in at the top level, e.g.
+//
+//	{"ts":"2024-01-02T15:04:05.000000001Z","level":"INFO","caller":"SYSTEM","msg":"hello","request_id":"abc"}
+func JSONFormatter(le Entry) (string, error) {
+	caller := le.Caller
+	if caller == "" {
+		caller = resolveCallerPC(le.CallerPC)
+	}
+
+	out := map[string]any{
+		"ts":     time.Now().Format(time.RFC3339Nano),
+		"level":  levelName[le.Level],
+		"caller": caller,
+		"msg":    strings.TrimSuffix(le.Entry, "\n"),
+	}
+	for k, v := range le.Fields {
+		out[k] = v
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
@@ -71,13 +71,22 @@ func getTodaysLogFilePath() string {
 
 // Returns a formatted entry based on the current logging level environment var.
 func defaultFormatByLevel(le Entry) (string, error) {
-	if len(le.Entry) == 0 || le.Entry[len(le.Entry)-1] != '\n' {
-		le.Entry += "\n"
+	msg := le.Entry
+	if len(le.Fields) > 0 {
+		msg = strings.TrimSuffix(msg, "\n") + " " + formatFields(le.Fields)
+	}
+	if len(msg) == 0 || msg[len(msg)-1] != '\n' {
+		msg += "\n"
+	}
+
+	caller := le.Caller
+	if caller == "" {
+		caller = resolveCallerPC(le.CallerPC)
 	}
 
 	now := time.Now().Format(TimeLayout)
 	tok := levelName[GetLogLevel()]
-	out := fmt.Sprintf("%s: [%s][%s] - %s", now, le.Caller, tok, le.Entry)
+	out := fmt.Sprintf("%s: [%s][%s] - %s", now, caller, tok, msg)
 
 	return out, nil
 }
@@ -87,8 +96,10 @@ func defaultFormatByLevel(le Entry) (string, error) {
 type LogFormatter func(Entry) (string, error)
 
 var (
-	fmtMu         sync.RWMutex
-	formatterFunc LogFormatter = defaultFormatByLevel
+	fmtMu            sync.RWMutex
+	formatterFunc    LogFormatter = defaultFormatByLevel
+	formatterName                 = "default"
+	formatterPattern string
 )
 
 // SetLogFormatter replaces the global fallback formatter used.
@@ -100,6 +111,38 @@ func SetLogFormatter(f LogFormatter) {
 	}
 	fmtMu.Lock()
 	formatterFunc = f
+	formatterName = "custom"
+	formatterPattern = ""
+	fmtMu.Unlock()
+}
+
+// getFormatterName and setFormatterName track the declarative name (as used
+// in config files) of the currently installed formatter, so DumpConfig can
+// report it. Calling SetLogFormatter directly resets it to "custom".
+func getFormatterName() string {
+	fmtMu.RLock()
+	defer fmtMu.RUnlock()
+	return formatterName
+}
+
+func setFormatterName(name string) {
+	fmtMu.Lock()
+	formatterName = name
+	fmtMu.Unlock()
+}
+
+// getFormatterPattern and setFormatterPattern track the pattern string
+// behind a "pattern" formatter installed via config, so DumpConfig can
+// round-trip it. Empty for any other formatter.
+func getFormatterPattern() string {
+	fmtMu.RLock()
+	defer fmtMu.RUnlock()
+	return formatterPattern
+}
+
+func setFormatterPattern(p string) {
+	fmtMu.Lock()
+	formatterPattern = p
 	fmtMu.Unlock()
 }
 
@@ -124,6 +167,16 @@ type Entry struct {
 	// What logging Level is required in the environment for the log to be
 	// written.
 	Level LogLevel
+
+	// Structured key/value context attached via LogEntryWith. Nil when the
+	// entry was produced by plain LogEntry.
+	Fields map[string]any
+
+	// CallerPC is set by LogEntryAuto instead of Caller; formatters resolve
+	// it into a string lazily via resolveCallerPC. Zero for entries built by
+	// LogEntry/LogEntryWith, and never meaningful outside this process, so
+	// it does not travel over EncodeFrame.
+	CallerPC uintptr `json:"-"`
 }
 
 // logger writes to a dated log file.
@@ -142,6 +195,17 @@ type logger struct {
 	maxWait  time.Duration
 	timer    *time.Timer
 
+	// Size/line rotation, tracked against the currently open file.
+	maxFileBytes int64
+	maxFileLines int
+	curBytes     int64
+	curLines     int
+	seq          int // monotonic segment number for l.date, reset on rotate()
+
+	// Retention sweeping.
+	maxRetentionDays int
+	stopSweep        chan struct{}
+
 	wg sync.WaitGroup
 }
 
@@ -164,12 +228,15 @@ func newLogger() *logger {
 		maxBytes: 512,
 		maxWait:  250 * time.Millisecond,
 		batchBuf: []string{},
+
+		stopSweep: make(chan struct{}),
 	}
 	l.timer = time.NewTimer(l.maxWait)
 	if !l.timer.Stop() {
 		<-l.timer.C
 	}
 	l.start()
+	l.startRetentionSweeper()
 
 	return l
 }
@@ -191,6 +258,9 @@ func (l *logger) rotate() {
 	l.file = f
 	l.date = getToday()
 	l.writer = bufio.NewWriter(l.file)
+	l.seq = 0
+	l.curBytes = 0
+	l.curLines = 0
 }
 
 func (l *logger) loop() {
@@ -220,6 +290,7 @@ func (l *logger) loop() {
 					msg = COULD_NOT_WRITE_ENTRY
 				}
 				l.writeToOut(msg)
+				dispatchToSinks(msg, *entry)
 			}
 
 			if getToday() != l.date {
@@ -262,6 +333,7 @@ func (l *logger) writeToOut(out string) {
 		} else {
 			l.writer.WriteString(out)
 			l.writer.Flush()
+			l.checkSizeLineRotation(len(out), strings.Count(out, "\n"))
 		}
 	}
 }
@@ -273,6 +345,7 @@ func (l *logger) appendItemToBatch(e *Entry) bool {
 	if err != nil {
 		msg = COULD_NOT_WRITE_ENTRY
 	}
+	dispatchToSinks(msg, *e)
 	l.batchBuf = append(l.batchBuf, msg)
 
 	if !(l.maxItems > 0 && len(l.batchBuf) >= l.maxItems) {
@@ -291,6 +364,7 @@ func (l *logger) appendBytesToBatch(e *Entry) bool {
 	if err != nil {
 		msg = COULD_NOT_WRITE_ENTRY
 	}
+	dispatchToSinks(msg, *e)
 	l.batchBuf = append(l.batchBuf, msg)
 
 	out := strings.Join(l.batchBuf, "")
@@ -310,6 +384,7 @@ func (l *logger) appendToTimer(e *Entry) {
 	if err != nil {
 		msg = COULD_NOT_WRITE_ENTRY
 	}
+	dispatchToSinks(msg, *e)
 	l.batchBuf = append(l.batchBuf, msg)
 
 	if l.maxWait > 0 {
@@ -339,12 +414,16 @@ func LogEntry(entry, caller string, level LogLevel) {
 		return
 	}
 
-	le := &Entry{
+	dispatchEntry(&Entry{
 		Caller: caller,
 		Entry:  entry,
 		Level:  level,
-	}
+	})
+}
 
+// dispatchEntry routes le to the logger according to the current batch
+// mode. It is the shared tail end of LogEntry and LogEntryWith.
+func dispatchEntry(le *Entry) {
 	if le.Level > GetLogLevel() {
 		return
 	}
@@ -386,8 +465,29 @@ func Shutdown() {
 
 	// Closing 'in' lets the loop's 'range' exit.
 	close(globalLogger.in)
+
+	// Stop the retention sweeper goroutine.
+	close(globalLogger.stopSweep)
+
+	// Flush/close every registered sink alongside the default output.
+	closeAllSinks()
 }
 
 func SetMaxItems(n int)          { globalLogger.maxItems = n }
 func SetMaxBytes(n int)          { globalLogger.maxBytes = n }
 func SetMaxWait(d time.Duration) { globalLogger.maxWait = d }
+
+// SetMaxFileBytes caps the size of the active log file. Once exceeded, the
+// file is rolled to a sequenced segment and a fresh file is opened. A value
+// of 0 disables size-based rotation.
+func SetMaxFileBytes(n int64) { globalLogger.maxFileBytes = n }
+
+// SetMaxFileLines caps the number of lines written to the active log file.
+// Once exceeded, the file is rolled to a sequenced segment and a fresh file
+// is opened. A value of 0 disables line-based rotation.
+func SetMaxFileLines(n int) { globalLogger.maxFileLines = n }
+
+// SetMaxRetentionDays sets how many days' worth of rotated log files are
+// kept; the background sweeper deletes anything older. A value of 0
+// disables retention sweeping.
+func SetMaxRetentionDays(d int) { globalLogger.maxRetentionDays = d }
@@ -0,0 +1,118 @@
+// Package netlog is the receiver counterpart to siglog.NetworkSink: it
+// accepts the frames a NetworkSink ships and re-logs them through a local
+// siglog logger, giving a centralized aggregation point without pulling in
+// syslog.
+package netlog
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+
+	"signal-weave/siglog"
+)
+
+// Server accepts framed Entry records shipped by a siglog.NetworkSink and
+// dispatches each to the local logger via siglog.LogEntry, or via
+// siglog.LogEntryWith when the entry carries Fields.
+type Server struct {
+	network string // "tcp" or "udp"
+	addr    string
+
+	listener   net.Listener
+	packetConn net.PacketConn
+}
+
+// NewServer prepares a receiver for network ("tcp" or "udp") addr. Call
+// Serve to start accepting connections; it blocks until Close is called.
+func NewServer(network, addr string) *Server {
+	return &Server{network: network, addr: addr}
+}
+
+// Serve listens for incoming frames and re-logs each one locally. For TCP it
+// spawns one goroutine per accepted connection; for UDP it reads datagrams
+// on the calling goroutine, since a datagram is already a complete frame.
+// Serve blocks until the listener errors, typically because Close was
+// called.
+func (s *Server) Serve() error {
+	if s.network == "udp" {
+		pc, err := net.ListenPacket("udp", s.addr)
+		if err != nil {
+			return err
+		}
+		s.packetConn = pc
+		return s.servePacket(pc)
+	}
+
+	ln, err := net.Listen(s.network, s.addr)
+	if err != nil {
+		return err
+	}
+	s.listener = ln
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go serveConn(conn)
+	}
+}
+
+// Close stops Serve, releasing whichever listener or packet conn is active.
+func (s *Server) Close() error {
+	if s.listener != nil {
+		return s.listener.Close()
+	}
+	if s.packetConn != nil {
+		return s.packetConn.Close()
+	}
+	return nil
+}
+
+func serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for {
+		e, err := siglog.DecodeFrame(r)
+		if err != nil {
+			return
+		}
+		reEmit(e)
+	}
+}
+
+func (s *Server) servePacket(pc net.PacketConn) error {
+	buf := make([]byte, 65535)
+	for {
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		e, err := siglog.DecodeFrame(bufio.NewReader(bytes.NewReader(buf[:n])))
+		if err != nil {
+			continue
+		}
+		reEmit(e)
+	}
+}
+
+// reEmit logs a decoded Entry through the local logger, carrying along
+// whatever structured Fields survived EncodeFrame/DecodeFrame. Unlike
+// CallerPC (see its doc comment on Entry), Fields is not excluded from the
+// JSON encoding and so travels over the wire, so dropping them here would silently defeat structured logging for every
+// entry shipped through a NetworkSink.
+func reEmit(e siglog.Entry) {
+	if len(e.Fields) == 0 {
+		siglog.LogEntry(e.Entry, e.Caller, e.Level)
+		return
+	}
+
+	fields := make([]siglog.Field, 0, len(e.Fields))
+	for k, v := range e.Fields {
+		fields = append(fields, siglog.F(k, v))
+	}
+	siglog.LogEntryWith(e.Caller, e.Level, e.Entry, fields...)
+}
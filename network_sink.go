@@ -0,0 +1,138 @@
+package siglog
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// netlogQueueSize bounds how many entries a NetworkSink will hold in memory
+// while its collector is unreachable or slow.
+const netlogQueueSize = 1024
+
+// dialTimeout bounds how long loop() waits on net.Dial. Without it, a
+// collector that is unreachable (as opposed to down-and-refusing) can leave
+// Close/Shutdown blocked for the OS connect timeout, which is far longer
+// than any operator expects a shutdown to take.
+const dialTimeout = 5 * time.Second
+
+// NetworkSink ships each Entry to a remote siglog/netlog receiver over TCP
+// or UDP as a length-prefixed JSON frame (see EncodeFrame). If the
+// collector is slow or down, sends are dropped once the in-memory queue
+// fills rather than blocking LogEntry, and the sink reconnects in the
+// background with exponential backoff.
+type NetworkSink struct {
+	network string // "tcp" or "udp"
+	addr    string
+
+	queue chan Entry
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// NewNetworkSink dials network ("tcp" or "udp") addr in the background and
+// returns a Sink that ships entries to it, reconnecting with exponential
+// backoff if the connection drops or is never established.
+func NewNetworkSink(network, addr string) *NetworkSink {
+	s := &NetworkSink{
+		network:    network,
+		addr:       addr,
+		queue:      make(chan Entry, netlogQueueSize),
+		done:       make(chan struct{}),
+		minBackoff: 100 * time.Millisecond,
+		maxBackoff: 10 * time.Second,
+	}
+	s.wg.Add(1)
+	go s.loop()
+	return s
+}
+
+// Write enqueues e for delivery. It never blocks: once the queue is full
+// the entry is dropped and an error is returned so the caller can log it.
+//
+// CallerPC is resolved into Caller before queuing: it is only meaningful in
+// this process (see the CallerPC doc comment on Entry) and is dropped by
+// EncodeFrame, so an entry built via LogEntryAuto would otherwise ship with
+// no caller information at all.
+func (s *NetworkSink) Write(_ string, e Entry) error {
+	if e.Caller == "" && e.CallerPC != 0 {
+		e.Caller = resolveCallerPC(e.CallerPC)
+	}
+
+	select {
+	case s.queue <- e:
+		return nil
+	default:
+		return fmt.Errorf("siglog: network sink queue full, dropping entry")
+	}
+}
+
+// Flush is a no-op: delivery is best-effort and asynchronous.
+func (s *NetworkSink) Flush() {}
+
+// Close stops the delivery goroutine and releases the connection.
+func (s *NetworkSink) Close() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+func (s *NetworkSink) loop() {
+	defer s.wg.Done()
+
+	backoff := s.minBackoff
+	for {
+		conn, err := net.DialTimeout(s.network, s.addr, dialTimeout)
+		if err != nil {
+			select {
+			case <-s.done:
+				return
+			case <-time.After(backoff):
+				backoff = nextBackoff(backoff, s.maxBackoff)
+				continue
+			}
+		}
+		backoff = s.minBackoff
+
+		if !s.drain(conn) {
+			_ = conn.Close()
+			return
+		}
+		_ = conn.Close()
+	}
+}
+
+// drain writes queued entries to conn until a write fails (triggering a
+// reconnect, returns true) or Close is called (returns false).
+func (s *NetworkSink) drain(conn net.Conn) bool {
+	w := bufio.NewWriter(conn)
+	for {
+		select {
+		case <-s.done:
+			return false
+		case e := <-s.queue:
+			frame, err := EncodeFrame(e)
+			if err != nil {
+				continue
+			}
+			if _, err := w.Write(frame); err != nil {
+				return true
+			}
+			if err := w.Flush(); err != nil {
+				return true
+			}
+		}
+	}
+}
+
+func nextBackoff(cur, max time.Duration) time.Duration {
+	next := cur * 2
+	if next > max {
+		return max
+	}
+	return next
+}
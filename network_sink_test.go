@@ -0,0 +1,35 @@
+package siglog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNetworkSink_WriteResolvesCallerPC(t *testing.T) {
+	s := &NetworkSink{queue: make(chan Entry, 1)}
+
+	pc := callerPC(2)
+	if err := s.Write("", Entry{Entry: "hi", Level: LL_ERROR, CallerPC: pc}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	queued := <-s.queue
+	if queued.Caller == "" {
+		t.Fatalf("expected Write to resolve CallerPC into Caller before queuing")
+	}
+	if !strings.Contains(queued.Caller, "network_sink_test.go:") {
+		t.Fatalf("expected caller to point at this test file, got %q", queued.Caller)
+	}
+}
+
+func TestNetworkSink_WriteLeavesExplicitCaller(t *testing.T) {
+	s := &NetworkSink{queue: make(chan Entry, 1)}
+
+	if err := s.Write("", Entry{Entry: "hi", Level: LL_ERROR, Caller: "SYSTEM"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if queued := <-s.queue; queued.Caller != "SYSTEM" {
+		t.Fatalf("expected explicit Caller to survive untouched, got %q", queued.Caller)
+	}
+}
@@ -0,0 +1,184 @@
+package siglog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// patternWriter appends one piece of a compiled pattern - a literal string
+// or a token's value - to sb for the given Entry.
+type patternWriter func(sb *strings.Builder, le Entry)
+
+var (
+	patternHostname = func() string {
+		h, err := os.Hostname()
+		if err != nil {
+			return "unknown"
+		}
+		return h
+	}()
+	patternPID = os.Getpid()
+)
+
+// PatternFormatter compiles a log4go-style pattern into a LogFormatter.
+// Recognized tokens:
+//
+//	%T        time, formatted with TimeLayout
+//	%L        level
+//	%S        source/caller
+//	%M        message, with any trailing newline trimmed
+//	%F{key}   a structured field by key, rendered empty if absent
+//	%G        goroutine id
+//	%H        hostname
+//	%P        process id
+//	%%        a literal '%'
+//
+// Anything else in pattern is copied through verbatim. The pattern is
+// parsed once here into a slice of writer thunks, so the LogFormatter it
+// returns does a single pass with no parsing or reflection per Entry. An
+// unrecognized token is rejected here, at compile time, rather than on
+// every subsequent log call.
+func PatternFormatter(pattern string) (LogFormatter, error) {
+	writers, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(le Entry) (string, error) {
+		var sb strings.Builder
+		for _, w := range writers {
+			w(&sb, le)
+		}
+		out := sb.String()
+		if len(out) == 0 || out[len(out)-1] != '\n' {
+			out += "\n"
+		}
+		return out, nil
+	}, nil
+}
+
+func compilePattern(pattern string) ([]patternWriter, error) {
+	var writers []patternWriter
+	var lit strings.Builder
+
+	flushLit := func() {
+		if lit.Len() == 0 {
+			return
+		}
+		s := lit.String()
+		writers = append(writers, func(sb *strings.Builder, _ Entry) {
+			sb.WriteString(s)
+		})
+		lit.Reset()
+	}
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			lit.WriteRune(runes[i])
+			continue
+		}
+
+		i++
+		if i >= len(runes) {
+			return nil, fmt.Errorf("siglog: pattern %q ends with a trailing %%", pattern)
+		}
+
+		switch runes[i] {
+		case '%':
+			lit.WriteByte('%')
+		case 'T':
+			flushLit()
+			writers = append(writers, func(sb *strings.Builder, _ Entry) {
+				sb.WriteString(time.Now().Format(TimeLayout))
+			})
+		case 'L':
+			flushLit()
+			writers = append(writers, func(sb *strings.Builder, le Entry) {
+				sb.WriteString(levelName[le.Level])
+			})
+		case 'S':
+			flushLit()
+			writers = append(writers, func(sb *strings.Builder, le Entry) {
+				caller := le.Caller
+				if caller == "" {
+					caller = resolveCallerPC(le.CallerPC)
+				}
+				sb.WriteString(caller)
+			})
+		case 'M':
+			flushLit()
+			writers = append(writers, func(sb *strings.Builder, le Entry) {
+				sb.WriteString(strings.TrimSuffix(le.Entry, "\n"))
+			})
+		case 'G':
+			flushLit()
+			writers = append(writers, func(sb *strings.Builder, _ Entry) {
+				sb.WriteString(strconv.Itoa(goroutineID()))
+			})
+		case 'H':
+			flushLit()
+			writers = append(writers, func(sb *strings.Builder, _ Entry) {
+				sb.WriteString(patternHostname)
+			})
+		case 'P':
+			flushLit()
+			writers = append(writers, func(sb *strings.Builder, _ Entry) {
+				sb.WriteString(strconv.Itoa(patternPID))
+			})
+		case 'F':
+			key, end, err := parseFieldKey(runes, i)
+			if err != nil {
+				return nil, fmt.Errorf("siglog: pattern %q: %w", pattern, err)
+			}
+			i = end
+			flushLit()
+			writers = append(writers, func(sb *strings.Builder, le Entry) {
+				if v, ok := le.Fields[key]; ok {
+					fmt.Fprintf(sb, "%v", v)
+				}
+			})
+		default:
+			return nil, fmt.Errorf("siglog: pattern %q: unknown token %%%c", pattern, runes[i])
+		}
+	}
+
+	flushLit()
+	return writers, nil
+}
+
+// parseFieldKey reads the "{key}" following a %F token, given the index of
+// the 'F' rune, and returns the key and the index of its closing brace.
+func parseFieldKey(runes []rune, fIdx int) (key string, closeIdx int, err error) {
+	if fIdx+1 >= len(runes) || runes[fIdx+1] != '{' {
+		return "", 0, fmt.Errorf("%%F must be followed by {key}")
+	}
+	for j := fIdx + 2; j < len(runes); j++ {
+		if runes[j] == '}' {
+			return string(runes[fIdx+2 : j]), j, nil
+		}
+	}
+	return "", 0, fmt.Errorf("%%F{...} missing closing brace")
+}
+
+// goroutineID parses the current goroutine's id out of its own stack
+// trace - there's no supported runtime API for it, but the trace always
+// starts with "goroutine <id> [...]".
+func goroutineID() int {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	b := bytes.TrimPrefix(buf[:n], []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, err := strconv.Atoi(string(b))
+	if err != nil {
+		return -1
+	}
+	return id
+}
@@ -0,0 +1,124 @@
+package siglog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPatternFormatter_RendersTokens(t *testing.T) {
+	f, err := PatternFormatter("[%L] %S - %M")
+	if err != nil {
+		t.Fatalf("PatternFormatter: %v", err)
+	}
+
+	out, err := f(Entry{Caller: "SYSTEM", Entry: "hello\n", Level: LL_INFO})
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	if out != "[INFO] SYSTEM - hello\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestPatternFormatter_FieldTokenAndEscape(t *testing.T) {
+	f, err := PatternFormatter("%M %F{request_id} 100%%")
+	if err != nil {
+		t.Fatalf("PatternFormatter: %v", err)
+	}
+
+	out, err := f(Entry{
+		Entry: "done",
+		Level: LL_INFO,
+		Fields: map[string]any{
+			"request_id": "abc123",
+		},
+	})
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	if out != "done abc123 100%\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestPatternFormatter_MissingFieldRendersEmpty(t *testing.T) {
+	f, err := PatternFormatter("[%F{missing}]")
+	if err != nil {
+		t.Fatalf("PatternFormatter: %v", err)
+	}
+
+	out, err := f(Entry{Entry: "x", Level: LL_INFO})
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	if out != "[]\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestPatternFormatter_RejectsUnknownToken(t *testing.T) {
+	if _, err := PatternFormatter("%Z"); err == nil {
+		t.Fatalf("expected an error for an unknown token")
+	}
+}
+
+func TestPatternFormatter_RejectsTrailingPercent(t *testing.T) {
+	if _, err := PatternFormatter("abc%"); err == nil {
+		t.Fatalf("expected an error for a trailing %%")
+	}
+}
+
+func TestPatternFormatter_RejectsUnclosedFieldBrace(t *testing.T) {
+	if _, err := PatternFormatter("%F{oops"); err == nil {
+		t.Fatalf("expected an error for an unclosed %%F brace")
+	}
+}
+
+func TestPatternFormatter_AppendsTrailingNewline(t *testing.T) {
+	f, err := PatternFormatter("%L [%S] %M")
+	if err != nil {
+		t.Fatalf("PatternFormatter: %v", err)
+	}
+
+	out, err := f(Entry{Caller: "SYSTEM", Entry: "hi", Level: LL_INFO})
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	if !strings.HasSuffix(out, "\n") {
+		t.Fatalf("expected a trailing newline, got %q", out)
+	}
+	if strings.HasSuffix(out, "\n\n") {
+		t.Fatalf("expected exactly one trailing newline, got %q", out)
+	}
+}
+
+func TestPatternFormatter_DoesNotDoubleTrailingNewline(t *testing.T) {
+	f, err := PatternFormatter("%M\n")
+	if err != nil {
+		t.Fatalf("PatternFormatter: %v", err)
+	}
+
+	out, err := f(Entry{Entry: "hi", Level: LL_INFO})
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	if out != "hi\n" {
+		t.Fatalf("expected a single trailing newline, got %q", out)
+	}
+}
+
+func TestPatternFormatter_PIDAndHostnameAreNonEmpty(t *testing.T) {
+	f, err := PatternFormatter("%P|%H")
+	if err != nil {
+		t.Fatalf("PatternFormatter: %v", err)
+	}
+
+	out, err := f(Entry{Entry: "x", Level: LL_INFO})
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	parts := strings.SplitN(out, "|", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		t.Fatalf("expected non-empty pid and hostname, got %q", out)
+	}
+}
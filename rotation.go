@@ -0,0 +1,117 @@
+package siglog
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// retentionSweepInterval is how often the background sweeper checks the log
+// directory for files older than the configured retention window.
+const retentionSweepInterval = 1 * time.Hour
+
+// mycelialogFileRe matches both the plain daily file (mycelia-log-<date>.log)
+// and size/line-rotated segments (mycelia-log-<date>.<seq>.log).
+var mycelialogFileRe = regexp.MustCompile(`^mycelia-log-(\d{2}-\d{2}-\d{4})(?:\.\d+)?\.log$`)
+
+// checkSizeLineRotation accounts for a write of writtenBytes bytes and
+// writtenLines newlines against the active file and rolls to a new segment
+// if either configured threshold has been exceeded.
+func (l *logger) checkSizeLineRotation(writtenBytes, writtenLines int) {
+	l.curBytes += int64(writtenBytes)
+	l.curLines += writtenLines
+
+	exceeded := (l.maxFileBytes > 0 && l.curBytes >= l.maxFileBytes) ||
+		(l.maxFileLines > 0 && l.curLines >= l.maxFileLines)
+	if !exceeded {
+		return
+	}
+
+	l.rotateBySize()
+}
+
+// rotateBySize closes the active file, renames it to a sequenced segment
+// (mycelia-log-<date>.<seq>.log), and opens a fresh file under the plain
+// dated name so writers keep appending without pausing. It composes with
+// the date-based rotate(): l.seq resets whenever rotate() runs.
+func (l *logger) rotateBySize() {
+	_ = l.writer.Flush()
+	_ = l.file.Close()
+
+	l.seq++
+	segment := filepath.Join(
+		getLogDirectory(), fmt.Sprintf("mycelia-log-%s.%d.log", l.date, l.seq),
+	)
+	if err := os.Rename(getTodaysLogFilePath(), segment); err != nil {
+		fmt.Fprintln(os.Stderr, "Could not roll log file to segment.")
+	}
+
+	f, err := os.OpenFile(
+		getTodaysLogFilePath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644,
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Could not create next log segment file.")
+		return
+	}
+	l.file = f
+	l.writer = bufio.NewWriter(l.file)
+	l.curBytes = 0
+	l.curLines = 0
+}
+
+// startRetentionSweeper launches a background goroutine that periodically
+// deletes rotated log files older than maxRetentionDays. It exits once
+// stopSweep is closed.
+func (l *logger) startRetentionSweeper() {
+	go func() {
+		ticker := time.NewTicker(retentionSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-l.stopSweep:
+				return
+			case <-ticker.C:
+				l.sweepExpiredLogs()
+			}
+		}
+	}()
+}
+
+// sweepExpiredLogs deletes mycelia-log-* files whose embedded date is older
+// than the configured retention window. It is a no-op when retention is
+// disabled (maxRetentionDays <= 0).
+func (l *logger) sweepExpiredLogs() {
+	if l.maxRetentionDays <= 0 {
+		return
+	}
+
+	dir := getLogDirectory()
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -l.maxRetentionDays)
+
+	for _, entry := range entries {
+		m := mycelialogFileRe.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+
+		fileDate, err := time.Parse(DateLayout, m[1])
+		if err != nil {
+			continue
+		}
+		if fileDate.Before(cutoff) {
+			_ = os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package siglog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSizeRotation_CreatesSegmentFile(t *testing.T) {
+	segment := filepath.Join(getLogDirectory(), fmt.Sprintf("mycelia-log-%s.1.log", getToday()))
+
+	t.Cleanup(func() {
+		SetMaxFileBytes(0)
+		SetMaxFileLines(0)
+		SetBatchMode(BATCH_NONE)
+		SetOutput(OUT_STDOUT)
+		SetLogLevel(LL_NONE)
+		_ = os.Remove(getTodaysLogFilePath())
+		_ = os.Remove(segment)
+	})
+
+	if err := SetLogLevel(LL_INFO); err != nil {
+		t.Fatalf("SetLogLevel: %v", err)
+	}
+	if err := SetOutput(OUT_FILE); err != nil {
+		t.Fatalf("SetOutput: %v", err)
+	}
+	if err := SetBatchMode(BATCH_NONE); err != nil {
+		t.Fatalf("SetBatchMode: %v", err)
+	}
+	SetMaxFileBytes(10) // small enough that a single entry trips it
+
+	// Earlier tests may have deleted the on-disk file out from under the
+	// still-open descriptor; reopen a clean one at the same path so this
+	// test doesn't depend on execution order.
+	globalLogger.rotate()
+
+	LogEntry("this entry alone exceeds the byte threshold", "SYSTEM", LL_INFO)
+	Flush()
+
+	if _, err := os.Stat(segment); err != nil {
+		t.Fatalf("expected rotated segment file %q to exist: %v", segment, err)
+	}
+}
+
+func TestSweepExpiredLogs_RemovesOnlyStaleFiles(t *testing.T) {
+	dir := getLogDirectory()
+
+	stale := filepath.Join(dir, fmt.Sprintf("mycelia-log-%s.log", time.Now().AddDate(0, 0, -10).Format(DateLayout)))
+	fresh := filepath.Join(dir, fmt.Sprintf("mycelia-log-%s.log", getToday()))
+
+	for _, p := range []string{stale, fresh} {
+		if err := os.WriteFile(p, []byte("x"), 0644); err != nil {
+			t.Fatalf("seed %q: %v", p, err)
+		}
+	}
+	t.Cleanup(func() {
+		_ = os.Remove(stale)
+		_ = os.Remove(fresh)
+		globalLogger.maxRetentionDays = 0
+	})
+
+	globalLogger.maxRetentionDays = 7
+	globalLogger.sweepExpiredLogs()
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("expected stale log file to be removed, stat err: %v", err)
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Fatalf("expected fresh log file to survive: %v", err)
+	}
+}
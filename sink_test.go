@@ -0,0 +1,87 @@
+package siglog
+
+import (
+	"os"
+	"sync"
+	"testing"
+)
+
+// memSink records every formatted line it receives; used to assert fan-out
+// and per-sink level filtering without touching stdout/stderr/disk.
+type memSink struct {
+	mu      sync.Mutex
+	lines   []string
+	closed  bool
+	flushed bool
+}
+
+func (m *memSink) Write(formatted string, _ Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lines = append(m.lines, formatted)
+	return nil
+}
+
+func (m *memSink) Flush() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.flushed = true
+}
+
+func (m *memSink) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+}
+
+func (m *memSink) snapshot() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]string, len(m.lines))
+	copy(out, m.lines)
+	return out
+}
+
+func TestAddSink_FanOutAndLevelFilter(t *testing.T) {
+	t.Cleanup(func() {
+		RemoveSink("errors-only")
+		RemoveSink("everything")
+		SetBatchMode(BATCH_NONE)
+		SetOutput(OUT_STDOUT)
+		SetLogLevel(LL_NONE)
+	})
+
+	if err := SetLogLevel(LL_DEBUG); err != nil {
+		t.Fatalf("SetLogLevel: %v", err)
+	}
+	if err := SetOutput(OUT_STDOUT); err != nil {
+		t.Fatalf("SetOutput: %v", err)
+	}
+	if err := SetBatchMode(BATCH_NONE); err != nil {
+		t.Fatalf("SetBatchMode: %v", err)
+	}
+
+	errSink := &memSink{}
+	allSink := &memSink{}
+	AddSink("errors-only", errSink, LL_ERROR)
+	AddSink("everything", allSink, LL_DEBUG)
+
+	got := captureWriter(&os.Stdout, func() {
+		LogEntry("boom", "SYSTEM", LL_ERROR)
+		LogEntry("chatty", "SYSTEM", LL_DEBUG)
+		Flush()
+	})
+	_ = got
+
+	if lines := errSink.snapshot(); len(lines) != 1 {
+		t.Fatalf("errors-only sink: expected 1 line, got %d: %v", len(lines), lines)
+	}
+	if lines := allSink.snapshot(); len(lines) != 2 {
+		t.Fatalf("everything sink: expected 2 lines, got %d: %v", len(lines), lines)
+	}
+
+	RemoveSink("errors-only")
+	if !errSink.closed {
+		t.Fatalf("expected RemoveSink to close the sink")
+	}
+}
@@ -0,0 +1,60 @@
+package siglog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchConfig loads path immediately, then watches it for changes and calls
+// LoadConfig(path) again on every write, until the returned stop function is
+// called. A reload that fails to parse or validate is reported to stderr and
+// leaves the previous configuration in place.
+func WatchConfig(path string) (stop func(), err error) {
+	if err := LoadConfig(path); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// Watch the containing directory rather than the file itself: editors
+	// commonly replace a file (write-rename) rather than write in place,
+	// which drops a watch held on the old inode.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer watcher.Close()
+
+		for {
+			select {
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(ev.Name) != filepath.Clean(path) {
+					continue
+				}
+				if ev.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				if err := LoadConfig(path); err != nil {
+					fmt.Fprintf(os.Stderr, "siglog: reload %q: %v\n", path, err)
+				}
+
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }, nil
+}
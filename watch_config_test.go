@@ -0,0 +1,47 @@
+package siglog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchConfig_ReloadsOnFileChange(t *testing.T) {
+	t.Cleanup(func() {
+		SetLogLevel(LL_NONE)
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "siglog.json")
+
+	if err := os.WriteFile(path, []byte(`{"level": "WARN"}`), 0644); err != nil {
+		t.Fatalf("write initial config: %v", err)
+	}
+
+	stop, err := WatchConfig(path)
+	if err != nil {
+		t.Fatalf("WatchConfig: %v", err)
+	}
+	t.Cleanup(stop)
+
+	if GetLogLevel() != LL_WARN {
+		t.Fatalf("expected initial load to apply level WARN, got %s", GetLogLevel())
+	}
+
+	if err := os.WriteFile(path, []byte(`{"level": "DEBUG"}`), 0644); err != nil {
+		t.Fatalf("rewrite config: %v", err)
+	}
+
+	deadline := time.After(2 * time.Second)
+	for {
+		if GetLogLevel() == LL_DEBUG {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected level DEBUG after reload, got %s", GetLogLevel())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}